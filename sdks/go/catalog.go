@@ -0,0 +1,196 @@
+package namaskah
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// Country describes a country supported by the verification catalog.
+type Country struct {
+	Code     string   `json:"code"`
+	Name     string   `json:"name"`
+	DialCode string   `json:"dial_code"`
+	Services []string `json:"services"`
+}
+
+// Service describes a verification service available for a given country,
+// including its price range.
+type Service struct {
+	Code     string  `json:"code"`
+	Name     string  `json:"name"`
+	MinPrice float64 `json:"min_price"`
+	MaxPrice float64 `json:"max_price"`
+}
+
+// Pricing describes the price range for a single service/country pair.
+type Pricing struct {
+	Service  string  `json:"service"`
+	Country  string  `json:"country"`
+	MinPrice float64 `json:"min_price"`
+	MaxPrice float64 `json:"max_price"`
+}
+
+// catalogCache is an in-memory, TTL-expiring cache keyed by string. Concurrent
+// lookups for the same key that miss the cache collapse into a single fetch,
+// singleflight-style.
+type catalogCache[T any] struct {
+	ttl time.Duration
+
+	mu         sync.Mutex
+	entries    map[string]catalogEntry[T]
+	inflight   map[string]*catalogCall[T]
+	generation int64
+}
+
+type catalogEntry[T any] struct {
+	value     T
+	expiresAt time.Time
+}
+
+type catalogCall[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+func newCatalogCache[T any](ttl time.Duration) *catalogCache[T] {
+	return &catalogCache[T]{
+		ttl:      ttl,
+		entries:  make(map[string]catalogEntry[T]),
+		inflight: make(map[string]*catalogCall[T]),
+	}
+}
+
+// get returns the cached value for key if it hasn't expired, otherwise it joins
+// (or starts) a single shared fetch for that key, singleflight-style. The shared
+// fetch always runs on a context.Background() of its own, detached from every
+// caller's ctx: one caller's cancellation or timeout must not abort the upstream
+// request for every other caller waiting on the same result. Each caller instead
+// races its own ctx against the shared fetch completing, so a caller with a
+// shorter deadline than the fetch takes can still return ctx.Err() promptly
+// without affecting anyone else.
+func (c *catalogCache[T]) get(ctx context.Context, key string, fetch func(context.Context) (T, error)) (T, error) {
+	var zero T
+
+	c.mu.Lock()
+	if entry, ok := c.entries[key]; ok && time.Now().Before(entry.expiresAt) {
+		c.mu.Unlock()
+		return entry.value, nil
+	}
+
+	call, leading := c.inflight[key], false
+	if call == nil {
+		if err := ctx.Err(); err != nil {
+			c.mu.Unlock()
+			return zero, err
+		}
+		call = &catalogCall[T]{done: make(chan struct{})}
+		c.inflight[key] = call
+		leading = true
+	}
+	generation := c.generation
+	c.mu.Unlock()
+
+	if leading {
+		go func() {
+			value, err := fetch(context.Background())
+
+			// Remove the inflight entry and (maybe) populate the cache before
+			// signaling done, so a new caller that misses both checks while
+			// this goroutine is between the fetch and the lock never joins an
+			// already-resolved call instead of starting a fresh one.
+			c.mu.Lock()
+			delete(c.inflight, key)
+			if err == nil && c.generation == generation {
+				c.entries[key] = catalogEntry[T]{value: value, expiresAt: time.Now().Add(c.ttl)}
+			}
+			c.mu.Unlock()
+
+			call.value, call.err = value, err
+			close(call.done)
+		}()
+	}
+
+	select {
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	case <-call.done:
+		return call.value, call.err
+	}
+}
+
+// invalidateAll clears every cached entry and bumps the generation counter, so
+// a fetch already in flight when this runs won't repopulate the cache with its
+// (now stale) result once it completes.
+func (c *catalogCache[T]) invalidateAll() {
+	c.mu.Lock()
+	c.entries = make(map[string]catalogEntry[T])
+	c.generation++
+	c.mu.Unlock()
+}
+
+// GetCountries fetches the country catalog, serving from cache when available.
+func (s *VerifyService) GetCountries(ctx context.Context) ([]Country, error) {
+	return s.countries.get(ctx, "", func(fetchCtx context.Context) ([]Country, error) {
+		resp, err := s.client.doRequest(fetchCtx, "GET", "/countries", nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		var result []Country
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return nil, err
+		}
+		return result, nil
+	})
+}
+
+// GetServices fetches the services supported for countryCode, serving from cache
+// when available.
+func (s *VerifyService) GetServices(ctx context.Context, countryCode string) ([]Service, error) {
+	return s.services.get(ctx, countryCode, func(fetchCtx context.Context) ([]Service, error) {
+		resp, err := s.client.doRequest(fetchCtx, "GET", "/countries/"+countryCode+"/services", nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		var result []Service
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return nil, err
+		}
+		return result, nil
+	})
+}
+
+// GetPricing fetches the price range for a service/country pair, serving from
+// cache when available.
+func (s *VerifyService) GetPricing(ctx context.Context, service, country string) (Pricing, error) {
+	return s.pricing.get(ctx, service+"|"+country, func(fetchCtx context.Context) (Pricing, error) {
+		resp, err := s.client.doRequest(fetchCtx, "GET", "/pricing", nil, map[string]string{
+			"service": service,
+			"country": country,
+		})
+		if err != nil {
+			return Pricing{}, err
+		}
+		defer resp.Body.Close()
+
+		var result Pricing
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return Pricing{}, err
+		}
+		return result, nil
+	})
+}
+
+// Refresh clears all cached catalog data (countries, services, pricing), forcing
+// the next lookup of each to hit the API.
+func (s *VerifyService) Refresh() {
+	s.countries.invalidateAll()
+	s.services.invalidateAll()
+	s.pricing.invalidateAll()
+}
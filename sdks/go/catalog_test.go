@@ -0,0 +1,293 @@
+package namaskah
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestVerifyService_GetCountries_CachesResult(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]Country{{Code: "US", Name: "United States"}})
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{APIKey: "test-key", BaseURL: server.URL, CatalogTTL: time.Hour})
+
+	for i := 0; i < 3; i++ {
+		countries, err := client.Verify.GetCountries(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(countries) != 1 || countries[0].Code != "US" {
+			t.Fatalf("Unexpected countries: %v", countries)
+		}
+	}
+
+	if calls != 1 {
+		t.Errorf("Expected a single upstream call, got %d", calls)
+	}
+}
+
+func TestVerifyService_GetCountries_ExpiresAfterTTL(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]Country{{Code: "US"}})
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{APIKey: "test-key", BaseURL: server.URL, CatalogTTL: 5 * time.Millisecond})
+
+	if _, err := client.Verify.GetCountries(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if _, err := client.Verify.GetCountries(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("Expected cache to expire and refetch, got %d calls", calls)
+	}
+}
+
+func TestVerifyService_GetCountries_ConcurrentAccessCollapsesToOneCall(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]Country{{Code: "US"}})
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{APIKey: "test-key", BaseURL: server.URL, CatalogTTL: time.Hour})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Verify.GetCountries(context.Background()); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}()
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("Expected concurrent lookups to collapse into a single call, got %d", calls)
+	}
+}
+
+func TestVerifyService_GetCountries_CancelledContextStillServesWarmCache(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]Country{{Code: "US"}})
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{APIKey: "test-key", BaseURL: server.URL, CatalogTTL: time.Hour})
+
+	if _, err := client.Verify.GetCountries(context.Background()); err != nil {
+		t.Fatalf("Unexpected error warming the cache: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	countries, err := client.Verify.GetCountries(ctx)
+	if err != nil {
+		t.Fatalf("Expected a warm cache hit to bypass ctx cancellation, got error: %v", err)
+	}
+	if len(countries) != 1 || countries[0].Code != "US" {
+		t.Fatalf("Unexpected countries: %v", countries)
+	}
+	if calls != 1 {
+		t.Errorf("Expected the cached value to be served without another upstream call, got %d calls", calls)
+	}
+}
+
+func TestVerifyService_GetCountries_FollowerContextCancellationReturnsEarly(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]Country{{Code: "US"}})
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(Config{APIKey: "test-key", BaseURL: server.URL, CatalogTTL: time.Hour})
+
+	// Leader: kicks off the slow fetch and never applies a deadline of its own.
+	go client.Verify.GetCountries(context.Background())
+	time.Sleep(10 * time.Millisecond)
+
+	// Follower: joins the in-flight fetch but has a short deadline that expires
+	// long before the leader's upstream request unblocks.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.Verify.GetCountries(ctx)
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatal("expected the follower to return ctx.Err(), got nil")
+	}
+	if elapsed > 200*time.Millisecond {
+		t.Errorf("expected follower to return promptly on ctx cancellation, took %v", elapsed)
+	}
+}
+
+func TestVerifyService_GetCountries_LeaderContextCancellationDoesNotPoisonFollower(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]Country{{Code: "US"}})
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{APIKey: "test-key", BaseURL: server.URL, CatalogTTL: time.Hour})
+
+	// Leader: its own ctx has a short deadline that will expire long before the
+	// upstream request unblocks, but that must not abort the shared fetch.
+	leaderCtx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	leaderDone := make(chan struct{})
+	go func() {
+		defer close(leaderDone)
+		client.Verify.GetCountries(leaderCtx)
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+
+	// Follower: no deadline at all, so it should wait out the slow upstream
+	// request and get the real result, unaffected by the leader's cancellation.
+	followerDone := make(chan struct {
+		countries []Country
+		err       error
+	})
+	go func() {
+		countries, err := client.Verify.GetCountries(context.Background())
+		followerDone <- struct {
+			countries []Country
+			err       error
+		}{countries, err}
+	}()
+
+	<-leaderDone
+	time.Sleep(30 * time.Millisecond)
+	close(release)
+
+	result := <-followerDone
+	if result.err != nil {
+		t.Fatalf("expected follower to succeed despite leader's ctx expiring, got: %v", result.err)
+	}
+	if len(result.countries) != 1 || result.countries[0].Code != "US" {
+		t.Fatalf("unexpected countries: %v", result.countries)
+	}
+}
+
+func TestVerifyService_Refresh_DuringInFlightFetchIsNotUndone(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		if n == 1 {
+			<-release
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]Country{{Code: "US"}})
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{APIKey: "test-key", BaseURL: server.URL, CatalogTTL: time.Hour})
+
+	firstDone := make(chan struct{})
+	go func() {
+		defer close(firstDone)
+		client.Verify.GetCountries(context.Background())
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	client.Verify.Refresh()
+	close(release)
+	<-firstDone
+
+	if _, err := client.Verify.GetCountries(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("Expected Refresh during an in-flight fetch to still force a fresh call afterward, got %d calls", calls)
+	}
+}
+
+func TestVerifyService_Refresh_ForcesRefetch(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]Country{{Code: "US"}})
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{APIKey: "test-key", BaseURL: server.URL, CatalogTTL: time.Hour})
+
+	if _, err := client.Verify.GetCountries(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	client.Verify.Refresh()
+	if _, err := client.Verify.GetCountries(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("Expected Refresh to force a refetch, got %d calls", calls)
+	}
+}
+
+func TestVerifyService_GetServices_CachesPerCountry(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]Service{{Code: "whatsapp"}})
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{APIKey: "test-key", BaseURL: server.URL, CatalogTTL: time.Hour})
+
+	if _, err := client.Verify.GetServices(context.Background(), "US"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := client.Verify.GetServices(context.Background(), "US"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := client.Verify.GetServices(context.Background(), "GB"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("Expected one call per distinct country, got %d", calls)
+	}
+}
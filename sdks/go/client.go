@@ -2,16 +2,125 @@ package namaskah
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"io"
+	"math/rand"
 	"net/http"
+	"strconv"
 	"time"
 )
 
+// sleepFunc waits for d, returning early if ctx is cancelled. It is a package
+// variable so tests can stub out real waiting and assert on computed delays.
+var sleepFunc = func(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+var defaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// RetryPolicy controls how Client retries failed requests.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first. Zero or one disables retries.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry; it doubles on each subsequent attempt. Defaults to 200ms.
+	BaseDelay time.Duration
+	// MaxDelay caps the exponential backoff delay before Jitter is added, so a
+	// jittered delay can exceed it by up to the Jitter fraction. Zero means uncapped.
+	MaxDelay time.Duration
+	// Jitter, between 0 and 1, randomizes the computed delay by up to that fraction.
+	Jitter float64
+	// RetryableStatusCodes lists HTTP status codes that trigger a retry. Defaults to 429, 500, 502, 503 and 504.
+	RetryableStatusCodes []int
+}
+
+func (p RetryPolicy) maxAttempts() int {
+	if p.MaxAttempts <= 0 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+func (p RetryPolicy) retryable(status int) bool {
+	codes := p.RetryableStatusCodes
+	if codes == nil {
+		codes = defaultRetryableStatusCodes
+	}
+	for _, c := range codes {
+		if c == status {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff computes the delay before the given (zero-indexed) retry attempt.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	base := p.BaseDelay
+	if base <= 0 {
+		base = 200 * time.Millisecond
+	}
+	delay := base * time.Duration(1<<uint(attempt))
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if p.Jitter > 0 {
+		delay += time.Duration(rand.Float64() * p.Jitter * float64(delay))
+	}
+	return delay
+}
+
+// retryAfter parses a Retry-After header value (seconds or HTTP-date) into a delay.
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			secs = 0
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
 type Config struct {
 	APIKey  string
 	BaseURL string
 	Timeout time.Duration
+	// RoundTripper, if set, is used as the underlying http.Client's Transport,
+	// letting callers inject logging, metrics, or test middleware.
+	RoundTripper http.RoundTripper
+	// RetryPolicy controls retry/backoff behavior for transient failures. The zero
+	// value disables retries.
+	RetryPolicy RetryPolicy
+	// CatalogTTL controls how long VerifyService caches country/service/pricing
+	// catalog lookups before refetching. Defaults to 5 minutes.
+	CatalogTTL time.Duration
 }
 
 type Client struct {
@@ -30,16 +139,25 @@ func NewClient(config Config) *Client {
 	if config.Timeout == 0 {
 		config.Timeout = 10 * time.Second
 	}
+	if config.CatalogTTL == 0 {
+		config.CatalogTTL = 5 * time.Minute
+	}
 
 	c := &Client{
 		httpClient: &http.Client{
-			Timeout: config.Timeout,
+			Timeout:   config.Timeout,
+			Transport: config.RoundTripper,
 		},
 		config: config,
 	}
 
 	// Services are initialized in NewClient
-	c.Verify = &VerifyService{client: c}
+	c.Verify = &VerifyService{
+		client:    c,
+		countries: newCatalogCache[[]Country](config.CatalogTTL),
+		services:  newCatalogCache[[]Service](config.CatalogTTL),
+		pricing:   newCatalogCache[Pricing](config.CatalogTTL),
+	}
 	c.Forwarding = &ForwardingService{client: c}
 	c.Users = &UsersService{client: c}
 	c.Referrals = &ReferralsService{client: c}
@@ -47,33 +165,82 @@ func NewClient(config Config) *Client {
 	return c
 }
 
-func (c *Client) doRequest(method, path string, body interface{}, params map[string]string) (*http.Response, error) {
-	var bodyReader io.Reader
+// doRequest issues an HTTP request, retrying on transient network errors and on
+// status codes the configured RetryPolicy marks as retryable.
+func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}, params map[string]string) (*http.Response, error) {
+	var bodyBytes []byte
 	if body != nil {
-		jsonData, err := json.Marshal(body)
+		b, err := json.Marshal(body)
 		if err != nil {
 			return nil, err
 		}
-		bodyReader = bytes.NewReader(jsonData)
+		bodyBytes = b
 	}
 
 	url := c.config.BaseURL + path
-	req, err := http.NewRequest(method, url, bodyReader)
-	if err != nil {
-		return nil, err
-	}
+	policy := c.config.RetryPolicy
+	attempts := policy.maxAttempts()
 
-	if params != nil {
-		q := req.URL.Query()
-		for k, v := range params {
-			q.Add(k, v)
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		var bodyReader io.Reader
+		if bodyBytes != nil {
+			bodyReader = bytes.NewReader(bodyBytes)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+		if err != nil {
+			return nil, err
 		}
-		req.URL.RawQuery = q.Encode()
-	}
 
-	req.Header.Set("Authorization", "Bearer "+c.config.APIKey)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-SDK-Client", "go-sdk-v1")
+		if params != nil {
+			q := req.URL.Query()
+			for k, v := range params {
+				q.Add(k, v)
+			}
+			req.URL.RawQuery = q.Encode()
+		}
+
+		req.Header.Set("Authorization", "Bearer "+c.config.APIKey)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-SDK-Client", "go-sdk-v1")
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			lastErr = err
+			if attempt == attempts-1 {
+				return nil, err
+			}
+			if err := sleepFunc(ctx, policy.backoff(attempt)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if attempt == attempts-1 || !policy.retryable(resp.StatusCode) {
+			if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+				return resp, nil
+			}
+			apiErr := decodeAPIError(resp)
+			resp.Body.Close()
+			return nil, apiErr
+		}
+
+		delay := policy.backoff(attempt)
+		if ra, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			delay = ra
+		}
+		lastErr = fmt.Errorf("namaskah: received retryable status %d", resp.StatusCode)
+		resp.Body.Close()
+
+		if err := sleepFunc(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
 
-	return c.httpClient.Do(req)
+	return nil, lastErr
 }
@@ -1,9 +1,11 @@
 package namaskah
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -109,3 +111,118 @@ func TestReferralsService_GetStats(t *testing.T) {
 		t.Errorf("Expected 5 referrals, got %d", stats.TotalReferrals)
 	}
 }
+
+func TestDoRequest_RetriesOnRetryableStatus(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&attempts, 1)
+		if n < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Verification{ID: "v1", Status: "pending"})
+	}))
+	defer server.Close()
+
+	var delays []time.Duration
+	restore := stubSleep(&delays)
+	defer restore()
+
+	client := NewClient(Config{
+		APIKey:  "test-key",
+		BaseURL: server.URL,
+		RetryPolicy: RetryPolicy{
+			MaxAttempts: 3,
+			BaseDelay:   10 * time.Millisecond,
+		},
+	})
+
+	verif, err := client.Verify.GetContext(context.Background(), "v1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if verif.ID != "v1" {
+		t.Errorf("Expected ID v1, got %s", verif.ID)
+	}
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts)
+	}
+	if len(delays) != 2 {
+		t.Fatalf("Expected 2 backoff delays, got %v", delays)
+	}
+	if delays[0] != 10*time.Millisecond || delays[1] != 20*time.Millisecond {
+		t.Errorf("Expected exponential backoff [10ms 20ms], got %v", delays)
+	}
+}
+
+func TestDoRequest_HonorsRetryAfterHeader(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Verification{ID: "v1"})
+	}))
+	defer server.Close()
+
+	var delays []time.Duration
+	restore := stubSleep(&delays)
+	defer restore()
+
+	client := NewClient(Config{
+		APIKey:      "test-key",
+		BaseURL:     server.URL,
+		RetryPolicy: RetryPolicy{MaxAttempts: 2},
+	})
+
+	if _, err := client.Verify.GetContext(context.Background(), "v1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(delays) != 1 || delays[0] != 2*time.Second {
+		t.Errorf("Expected Retry-After delay of 2s, got %v", delays)
+	}
+}
+
+func TestDoRequest_ContextCancellationShortCircuits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	restore := stubSleep(nil)
+	defer restore()
+
+	client := NewClient(Config{
+		APIKey:      "test-key",
+		BaseURL:     server.URL,
+		RetryPolicy: RetryPolicy{MaxAttempts: 5, BaseDelay: time.Millisecond},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.Verify.GetContext(ctx, "v1")
+	if err == nil {
+		t.Fatal("Expected context cancellation error")
+	}
+}
+
+// stubSleep replaces sleepFunc with one that records delays instead of waiting,
+// returning a function that restores the original.
+func stubSleep(delays *[]time.Duration) func() {
+	original := sleepFunc
+	sleepFunc = func(ctx context.Context, d time.Duration) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if delays != nil {
+			*delays = append(*delays, d)
+		}
+		return nil
+	}
+	return func() { sleepFunc = original }
+}
@@ -0,0 +1,81 @@
+package namaskah
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// APIError represents a structured error response from the namaskah API.
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	RequestID  string
+	Details    map[string]interface{}
+}
+
+func (e *APIError) Error() string {
+	if e.RequestID != "" {
+		return fmt.Sprintf("namaskah: %s (status %d, request %s): %s", e.Code, e.StatusCode, e.RequestID, e.Message)
+	}
+	return fmt.Sprintf("namaskah: %s (status %d): %s", e.Code, e.StatusCode, e.Message)
+}
+
+// Is lets errors.Is match an *APIError against a sentinel like ErrInsufficientBalance
+// by comparing Code, so callers don't need to compare StatusCode/Message/RequestID too.
+func (e *APIError) Is(target error) bool {
+	t, ok := target.(*APIError)
+	if !ok || t.Code == "" {
+		return false
+	}
+	return e.Code == t.Code
+}
+
+// Sentinel errors for well-known API error codes, usable with errors.Is, e.g.
+// errors.Is(err, namaskah.ErrInsufficientBalance).
+var (
+	ErrInsufficientBalance     = &APIError{Code: "insufficient_balance"}
+	ErrRateLimited             = &APIError{Code: "rate_limited"}
+	ErrVerificationNotFound    = &APIError{Code: "verification_not_found"}
+	ErrInvalidWebhookSignature = &APIError{Code: "invalid_webhook_signature"}
+)
+
+// errorEnvelope is the standard error shape the namaskah API returns for
+// non-2xx responses.
+type errorEnvelope struct {
+	Error struct {
+		Code      string                 `json:"code"`
+		Message   string                 `json:"message"`
+		RequestID string                 `json:"request_id"`
+		Details   map[string]interface{} `json:"details"`
+	} `json:"error"`
+}
+
+// decodeAPIError reads and parses a non-2xx response body into an *APIError. If
+// the body doesn't match the standard envelope, the raw body becomes the message
+// and Code is set to "unknown".
+func decodeAPIError(resp *http.Response) error {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("namaskah: reading error response: %w", err)
+	}
+
+	var envelope errorEnvelope
+	if err := json.Unmarshal(body, &envelope); err != nil || envelope.Error.Code == "" {
+		return &APIError{
+			StatusCode: resp.StatusCode,
+			Code:       "unknown",
+			Message:    string(body),
+		}
+	}
+
+	return &APIError{
+		StatusCode: resp.StatusCode,
+		Code:       envelope.Error.Code,
+		Message:    envelope.Error.Message,
+		RequestID:  envelope.Error.RequestID,
+		Details:    envelope.Error.Details,
+	}
+}
@@ -0,0 +1,100 @@
+package namaskah
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func errorServer(t *testing.T, statusCode int, code, message, requestID string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error": map[string]interface{}{
+				"code":       code,
+				"message":    message,
+				"request_id": requestID,
+			},
+		})
+	}))
+}
+
+func TestAPIError_SentinelMatching(t *testing.T) {
+	cases := []struct {
+		code     string
+		sentinel error
+	}{
+		{"insufficient_balance", ErrInsufficientBalance},
+		{"rate_limited", ErrRateLimited},
+		{"verification_not_found", ErrVerificationNotFound},
+		{"invalid_webhook_signature", ErrInvalidWebhookSignature},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.code, func(t *testing.T) {
+			server := errorServer(t, http.StatusBadRequest, tc.code, "boom", "req-1")
+			defer server.Close()
+
+			client := NewClient(Config{APIKey: "test-key", BaseURL: server.URL})
+			_, err := client.Verify.GetContext(context.Background(), "v1")
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if !errors.Is(err, tc.sentinel) {
+				t.Errorf("expected errors.Is to match %s, got %v", tc.code, err)
+			}
+		})
+	}
+}
+
+func TestAPIError_AsUnwrapping(t *testing.T) {
+	server := errorServer(t, http.StatusPaymentRequired, "insufficient_balance", "top up your account", "req-42")
+	defer server.Close()
+
+	client := NewClient(Config{APIKey: "test-key", BaseURL: server.URL})
+	_, err := client.Verify.GetContext(context.Background(), "v1")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected errors.As to unwrap an *APIError, got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusPaymentRequired {
+		t.Errorf("expected status 402, got %d", apiErr.StatusCode)
+	}
+	if apiErr.RequestID != "req-42" {
+		t.Errorf("expected request id req-42, got %s", apiErr.RequestID)
+	}
+	if apiErr.Message != "top up your account" {
+		t.Errorf("expected message to be preserved, got %s", apiErr.Message)
+	}
+}
+
+func TestAPIError_UnknownBodyFallsBackToRawMessage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("upstream exploded"))
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{APIKey: "test-key", BaseURL: server.URL})
+	_, err := client.Verify.GetContext(context.Background(), "v1")
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an *APIError, got %v", err)
+	}
+	if apiErr.Code != "unknown" {
+		t.Errorf("expected code unknown, got %s", apiErr.Code)
+	}
+	if apiErr.Message != "upstream exploded" {
+		t.Errorf("expected raw body as message, got %s", apiErr.Message)
+	}
+}
@@ -1,6 +1,9 @@
 package namaskah
 
-import "encoding/json"
+import (
+	"context"
+	"encoding/json"
+)
 
 // ForwardingService handles forwarding configuration
 type ForwardingService struct {
@@ -16,8 +19,15 @@ type ForwardingConfig struct {
 	ForwardAll     bool   `json:"forward_all"`
 }
 
+// GetConfig fetches the forwarding config. It is equivalent to GetConfigContext
+// with context.Background().
 func (s *ForwardingService) GetConfig() (*ForwardingConfig, error) {
-	resp, err := s.client.doRequest("GET", "/forwarding", nil, nil)
+	return s.GetConfigContext(context.Background())
+}
+
+// GetConfigContext fetches the forwarding config, honoring ctx cancellation and deadlines.
+func (s *ForwardingService) GetConfigContext(ctx context.Context) (*ForwardingConfig, error) {
+	resp, err := s.client.doRequest(ctx, "GET", "/forwarding", nil, nil)
 	if err != nil {
 		return nil, err
 	}
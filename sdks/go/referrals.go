@@ -1,6 +1,12 @@
 package namaskah
 
-import "encoding/json"
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"strconv"
+	"time"
+)
 
 // ReferralsService handles referral related tasks
 type ReferralsService struct {
@@ -18,8 +24,56 @@ type Referral struct {
 	CreatedAt string `json:"created_at"`
 }
 
+// ReferralListOptions filters and paginates ReferralsService.List.
+type ReferralListOptions struct {
+	// Status, if set, restricts results to referrals in that status.
+	Status string
+	// Since and Until, if non-zero, restrict results to referrals created within
+	// that date range.
+	Since time.Time
+	Until time.Time
+	// Limit caps the number of items returned per page.
+	Limit int
+	// Cursor resumes listing from a previous ReferralPage.NextCursor.
+	Cursor string
+}
+
+// ReferralPage is one page of a ReferralsService.List result.
+type ReferralPage struct {
+	Items      []Referral `json:"items"`
+	NextCursor string     `json:"next_cursor"`
+	HasMore    bool       `json:"has_more"`
+}
+
+func (o ReferralListOptions) queryParams() map[string]string {
+	params := map[string]string{}
+	if o.Status != "" {
+		params["status"] = o.Status
+	}
+	if !o.Since.IsZero() {
+		params["since"] = o.Since.Format(time.RFC3339)
+	}
+	if !o.Until.IsZero() {
+		params["until"] = o.Until.Format(time.RFC3339)
+	}
+	if o.Limit > 0 {
+		params["limit"] = strconv.Itoa(o.Limit)
+	}
+	if o.Cursor != "" {
+		params["cursor"] = o.Cursor
+	}
+	return params
+}
+
+// GetStats fetches referral stats. It is equivalent to GetStatsContext with
+// context.Background().
 func (s *ReferralsService) GetStats() (*ReferralStats, error) {
-	resp, err := s.client.doRequest("GET", "/referrals/stats", nil, nil)
+	return s.GetStatsContext(context.Background())
+}
+
+// GetStatsContext fetches referral stats, honoring ctx cancellation and deadlines.
+func (s *ReferralsService) GetStatsContext(ctx context.Context) (*ReferralStats, error) {
+	resp, err := s.client.doRequest(ctx, "GET", "/referrals/stats", nil, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -32,16 +86,56 @@ func (s *ReferralsService) GetStats() (*ReferralStats, error) {
 	return &result, nil
 }
 
-func (s *ReferralsService) List() ([]Referral, error) {
-	resp, err := s.client.doRequest("GET", "/referrals/list", nil, nil)
+// List fetches one page of the referral history matching opts. It is equivalent
+// to ListContext with context.Background().
+func (s *ReferralsService) List(opts ReferralListOptions) (*ReferralPage, error) {
+	return s.ListContext(context.Background(), opts)
+}
+
+// ListContext fetches one page of the referral history matching opts, honoring
+// ctx cancellation and deadlines.
+func (s *ReferralsService) ListContext(ctx context.Context, opts ReferralListOptions) (*ReferralPage, error) {
+	resp, err := s.client.doRequest(ctx, "GET", "/referrals/list", nil, opts.queryParams())
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	var result []Referral
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	var page ReferralPage
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
 		return nil, err
 	}
-	return result, nil
+	return &page, nil
+}
+
+// ListAll returns an iterator over every referral matching opts, transparently
+// walking pages as the caller consumes them:
+//
+//	for r, err := range client.Referrals.ListAll(ctx, opts) {
+//		if err != nil {
+//			// handle and break
+//		}
+//	}
+func (s *ReferralsService) ListAll(ctx context.Context, opts ReferralListOptions) iter.Seq2[Referral, error] {
+	return func(yield func(Referral, error) bool) {
+		cur := opts
+		for {
+			page, err := s.ListContext(ctx, cur)
+			if err != nil {
+				yield(Referral{}, err)
+				return
+			}
+
+			for _, r := range page.Items {
+				if !yield(r, nil) {
+					return
+				}
+			}
+
+			if !page.HasMore || page.NextCursor == "" {
+				return
+			}
+			cur.Cursor = page.NextCursor
+		}
+	}
 }
@@ -0,0 +1,116 @@
+package namaskah
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func multiPageReferralServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	pages := map[string]ReferralPage{
+		"": {
+			Items:      []Referral{{ID: "r1"}, {ID: "r2"}},
+			NextCursor: "page2",
+			HasMore:    true,
+		},
+		"page2": {
+			Items:      []Referral{{ID: "r3"}},
+			NextCursor: "",
+			HasMore:    false,
+		},
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cursor := r.URL.Query().Get("cursor")
+		page, ok := pages[cursor]
+		if !ok {
+			t.Fatalf("unexpected cursor %q", cursor)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(page)
+	}))
+}
+
+func TestReferralsService_ListAll_WalksPages(t *testing.T) {
+	server := multiPageReferralServer(t)
+	defer server.Close()
+
+	client := NewClient(Config{APIKey: "test-key", BaseURL: server.URL})
+
+	var ids []string
+	for r, err := range client.Referrals.ListAll(context.Background(), ReferralListOptions{}) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, r.ID)
+	}
+
+	if len(ids) != 3 || ids[0] != "r1" || ids[1] != "r2" || ids[2] != "r3" {
+		t.Errorf("expected [r1 r2 r3], got %v", ids)
+	}
+}
+
+func TestReferralsService_ListAll_StopsOnEmptyCursor(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ReferralPage{Items: nil, NextCursor: "", HasMore: false})
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{APIKey: "test-key", BaseURL: server.URL})
+
+	count := 0
+	for range client.Referrals.ListAll(context.Background(), ReferralListOptions{}) {
+		count++
+	}
+	if count != 0 {
+		t.Errorf("expected no items, got %d", count)
+	}
+}
+
+func TestReferralsService_ListAll_PropagatesErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("boom"))
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{APIKey: "test-key", BaseURL: server.URL})
+
+	var gotErr error
+	for _, err := range client.Referrals.ListAll(context.Background(), ReferralListOptions{}) {
+		gotErr = err
+		break
+	}
+
+	var apiErr *APIError
+	if gotErr == nil || !errors.As(gotErr, &apiErr) {
+		t.Fatalf("expected an *APIError, got %v", gotErr)
+	}
+}
+
+func TestReferralsService_ListAll_StopsEarlyWhenCallerBreaks(t *testing.T) {
+	server := multiPageReferralServer(t)
+	defer server.Close()
+
+	client := NewClient(Config{APIKey: "test-key", BaseURL: server.URL})
+
+	var ids []string
+	for r, err := range client.Referrals.ListAll(context.Background(), ReferralListOptions{}) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, r.ID)
+		if len(ids) == 1 {
+			break
+		}
+	}
+
+	if len(ids) != 1 || ids[0] != "r1" {
+		t.Errorf("expected iteration to stop after first item, got %v", ids)
+	}
+}
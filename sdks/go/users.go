@@ -1,6 +1,9 @@
 package namaskah
 
-import "encoding/json"
+import (
+	"context"
+	"encoding/json"
+)
 
 // UsersService handles user related tasks
 type UsersService struct {
@@ -17,8 +20,15 @@ type Balance struct {
 	Currency string  `json:"currency"`
 }
 
+// GetProfile fetches the user's profile. It is equivalent to GetProfileContext
+// with context.Background().
 func (s *UsersService) GetProfile() (*UserProfile, error) {
-	resp, err := s.client.doRequest("GET", "/user/profile", nil, nil)
+	return s.GetProfileContext(context.Background())
+}
+
+// GetProfileContext fetches the user's profile, honoring ctx cancellation and deadlines.
+func (s *UsersService) GetProfileContext(ctx context.Context) (*UserProfile, error) {
+	resp, err := s.client.doRequest(ctx, "GET", "/user/profile", nil, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -31,8 +41,15 @@ func (s *UsersService) GetProfile() (*UserProfile, error) {
 	return &result, nil
 }
 
+// GetBalance fetches the user's balance. It is equivalent to GetBalanceContext
+// with context.Background().
 func (s *UsersService) GetBalance() (*Balance, error) {
-	resp, err := s.client.doRequest("GET", "/billing/balance", nil, nil)
+	return s.GetBalanceContext(context.Background())
+}
+
+// GetBalanceContext fetches the user's balance, honoring ctx cancellation and deadlines.
+func (s *UsersService) GetBalanceContext(ctx context.Context) (*Balance, error) {
+	resp, err := s.client.doRequest(ctx, "GET", "/billing/balance", nil, nil)
 	if err != nil {
 		return nil, err
 	}
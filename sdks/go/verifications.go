@@ -1,10 +1,17 @@
 package namaskah
 
-import "encoding/json"
+import (
+	"context"
+	"encoding/json"
+)
 
 // VerifyService handles verification related tasks
 type VerifyService struct {
 	client *Client
+
+	countries *catalogCache[[]Country]
+	services  *catalogCache[[]Service]
+	pricing   *catalogCache[Pricing]
 }
 
 // Verification represents a verification request/response
@@ -24,8 +31,15 @@ type CreateVerificationRequest struct {
 	Country string `json:"country"`
 }
 
+// Create creates a verification. It is equivalent to CreateContext with
+// context.Background().
 func (s *VerifyService) Create(req CreateVerificationRequest) (*Verification, error) {
-	resp, err := s.client.doRequest("POST", "/verify", req, nil)
+	return s.CreateContext(context.Background(), req)
+}
+
+// CreateContext creates a verification, honoring ctx cancellation and deadlines.
+func (s *VerifyService) CreateContext(ctx context.Context, req CreateVerificationRequest) (*Verification, error) {
+	resp, err := s.client.doRequest(ctx, "POST", "/verify", req, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -38,8 +52,14 @@ func (s *VerifyService) Create(req CreateVerificationRequest) (*Verification, er
 	return &result, nil
 }
 
+// Get fetches a verification. It is equivalent to GetContext with context.Background().
 func (s *VerifyService) Get(id string) (*Verification, error) {
-	resp, err := s.client.doRequest("GET", "/verify/"+id, nil, nil)
+	return s.GetContext(context.Background(), id)
+}
+
+// GetContext fetches a verification, honoring ctx cancellation and deadlines.
+func (s *VerifyService) GetContext(ctx context.Context, id string) (*Verification, error) {
+	resp, err := s.client.doRequest(ctx, "GET", "/verify/"+id, nil, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -52,25 +72,17 @@ func (s *VerifyService) Get(id string) (*Verification, error) {
 	return &result, nil
 }
 
+// Cancel cancels a verification. It is equivalent to CancelContext with context.Background().
 func (s *VerifyService) Cancel(id string) error {
-	resp, err := s.client.doRequest("POST", "/verify/"+id+"/cancel", nil, nil)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-	return nil
+	return s.CancelContext(context.Background(), id)
 }
 
-func (s *VerifyService) GetCountries() (interface{}, error) {
-	resp, err := s.client.doRequest("GET", "/countries", nil, nil)
+// CancelContext cancels a verification, honoring ctx cancellation and deadlines.
+func (s *VerifyService) CancelContext(ctx context.Context, id string) error {
+	resp, err := s.client.doRequest(ctx, "POST", "/verify/"+id+"/cancel", nil, nil)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer resp.Body.Close()
-
-	var result interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, err
-	}
-	return result, nil
+	return nil
 }
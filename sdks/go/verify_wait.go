@@ -0,0 +1,97 @@
+package namaskah
+
+import (
+	"context"
+	"regexp"
+	"time"
+)
+
+// terminalVerificationStatuses lists the statuses WaitForCode treats as final,
+// i.e. no further SMS delivery is expected.
+var terminalVerificationStatuses = map[string]bool{
+	"completed": true,
+	"cancelled": true,
+	"expired":   true,
+	"failed":    true,
+}
+
+var defaultCodePattern = regexp.MustCompile(`\b\d{4,8}\b`)
+
+// DefaultCodeExtractor finds the last run of 4-8 digits in smsText, a common
+// shape for verification codes, and returns false if none is found.
+func DefaultCodeExtractor(smsText string) (string, bool) {
+	matches := defaultCodePattern.FindAllString(smsText, -1)
+	if len(matches) == 0 {
+		return "", false
+	}
+	return matches[len(matches)-1], true
+}
+
+// WaitForCodeOptions configures VerifyService.WaitForCode.
+type WaitForCodeOptions struct {
+	// PollInterval is the initial delay between polls; it doubles after each
+	// attempt up to MaxInterval. Defaults to 1 second.
+	PollInterval time.Duration
+	// MaxInterval caps the poll interval. Defaults to 10 seconds.
+	MaxInterval time.Duration
+	// Timeout bounds the total time spent waiting. Zero means wait until ctx
+	// is cancelled or a terminal result arrives.
+	Timeout time.Duration
+	// CodeExtractor extracts an SMS code from SMSText when the upstream SMSCode
+	// field is empty. Defaults to DefaultCodeExtractor.
+	CodeExtractor func(smsText string) (string, bool)
+}
+
+// WaitForCode polls Get(id) until the verification reaches a terminal status or
+// an SMS code becomes available, whichever comes first. If the upstream SMSCode
+// field is empty but SMSText is populated, CodeExtractor is used to pull a code
+// out of the message text.
+func (s *VerifyService) WaitForCode(ctx context.Context, id string, opts WaitForCodeOptions) (*Verification, error) {
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	maxInterval := opts.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = 10 * time.Second
+	}
+	if interval > maxInterval {
+		interval = maxInterval
+	}
+	extractor := opts.CodeExtractor
+	if extractor == nil {
+		extractor = DefaultCodeExtractor
+	}
+
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	for {
+		verif, err := s.GetContext(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if verif.SMSCode == "" && verif.SMSText != "" {
+			if code, ok := extractor(verif.SMSText); ok {
+				verif.SMSCode = code
+			}
+		}
+
+		if terminalVerificationStatuses[verif.Status] || verif.SMSCode != "" {
+			return verif, nil
+		}
+
+		if err := sleepFunc(ctx, interval); err != nil {
+			return nil, err
+		}
+
+		interval *= 2
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package namaskah
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWaitForCode_ReturnsOnTerminalStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Verification{ID: "v1", Status: "completed"})
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{APIKey: "test-key", BaseURL: server.URL})
+
+	verif, err := client.Verify.WaitForCode(context.Background(), "v1", WaitForCodeOptions{
+		PollInterval: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if verif.Status != "completed" {
+		t.Errorf("Expected completed status, got %s", verif.Status)
+	}
+}
+
+func TestWaitForCode_PollsUntilSMSCodeArrives(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			json.NewEncoder(w).Encode(Verification{ID: "v1", Status: "pending"})
+			return
+		}
+		json.NewEncoder(w).Encode(Verification{ID: "v1", Status: "pending", SMSCode: "482910"})
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{APIKey: "test-key", BaseURL: server.URL})
+
+	verif, err := client.Verify.WaitForCode(context.Background(), "v1", WaitForCodeOptions{
+		PollInterval: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if verif.SMSCode != "482910" {
+		t.Errorf("Expected SMS code 482910, got %s", verif.SMSCode)
+	}
+}
+
+func TestWaitForCode_ExtractsCodeFromSMSText(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Verification{ID: "v1", Status: "pending", SMSText: "Your verification code is 73210, expires in 5 minutes"})
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{APIKey: "test-key", BaseURL: server.URL})
+
+	verif, err := client.Verify.WaitForCode(context.Background(), "v1", WaitForCodeOptions{
+		PollInterval: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if verif.SMSCode != "73210" {
+		t.Errorf("Expected extracted code 73210, got %s", verif.SMSCode)
+	}
+}
+
+func TestWaitForCode_CustomExtractor(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Verification{ID: "v1", Status: "pending", SMSText: "code=XZ-991"})
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{APIKey: "test-key", BaseURL: server.URL})
+
+	custom := func(smsText string) (string, bool) {
+		if smsText == "code=XZ-991" {
+			return "XZ-991", true
+		}
+		return "", false
+	}
+
+	verif, err := client.Verify.WaitForCode(context.Background(), "v1", WaitForCodeOptions{
+		PollInterval:  time.Millisecond,
+		CodeExtractor: custom,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if verif.SMSCode != "XZ-991" {
+		t.Errorf("Expected custom-extracted code XZ-991, got %s", verif.SMSCode)
+	}
+}
+
+func TestWaitForCode_TimesOut(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Verification{ID: "v1", Status: "pending"})
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{APIKey: "test-key", BaseURL: server.URL})
+
+	_, err := client.Verify.WaitForCode(context.Background(), "v1", WaitForCodeOptions{
+		PollInterval: 2 * time.Millisecond,
+		Timeout:      10 * time.Millisecond,
+	})
+	if err == nil {
+		t.Fatal("Expected timeout error")
+	}
+}
+
+func TestWaitForCode_CancellationStopsPolling(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Verification{ID: "v1", Status: "pending"})
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{APIKey: "test-key", BaseURL: server.URL})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		cancel()
+	}()
+
+	_, err := client.Verify.WaitForCode(ctx, "v1", WaitForCodeOptions{
+		PollInterval: 2 * time.Millisecond,
+	})
+	if err == nil {
+		t.Fatal("Expected cancellation error")
+	}
+}
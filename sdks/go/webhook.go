@@ -0,0 +1,235 @@
+package namaskah
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	signatureHeader = "X-Namaskah-Signature"
+	timestampHeader = "X-Namaskah-Timestamp"
+)
+
+// Logger is the minimal logging interface used by WebhookHandler.
+type Logger interface {
+	Printf(format string, args ...interface{})
+}
+
+type noopLogger struct{}
+
+func (noopLogger) Printf(string, ...interface{}) {}
+
+// SMSReceivedEvent is delivered when a verification receives an inbound SMS.
+type SMSReceivedEvent struct {
+	VerificationID string `json:"verification_id"`
+	PhoneNumber    string `json:"phone_number"`
+	SMSText        string `json:"sms_text"`
+	SMSCode        string `json:"sms_code,omitempty"`
+}
+
+// VerificationCompletedEvent is delivered when a verification reaches a terminal success state.
+type VerificationCompletedEvent struct {
+	VerificationID string `json:"verification_id"`
+	Status         string `json:"status"`
+}
+
+// VerificationCancelledEvent is delivered when a verification is cancelled.
+type VerificationCancelledEvent struct {
+	VerificationID string `json:"verification_id"`
+	Reason         string `json:"reason,omitempty"`
+}
+
+// ForwardingFailedEvent is delivered when delivering a verification to a configured
+// forwarding target (email or webhook) fails.
+type ForwardingFailedEvent struct {
+	VerificationID string `json:"verification_id"`
+	Target         string `json:"target"`
+	Error          string `json:"error"`
+}
+
+// webhookEnvelope is the outer shape every namaskah webhook delivery is wrapped in.
+type webhookEnvelope struct {
+	Type      string          `json:"type"`
+	Timestamp int64           `json:"timestamp"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// WebhookHandlerConfig configures a WebhookHandler.
+type WebhookHandlerConfig struct {
+	// Secret is the WebhookSecret configured on ForwardingConfig; required.
+	Secret string
+	// MaxSkew bounds how far the X-Namaskah-Timestamp header may drift from
+	// Clock() before a request is rejected as a possible replay. Defaults to
+	// 5 minutes.
+	MaxSkew time.Duration
+	// Clock returns the current time; defaults to time.Now. Overridable in tests.
+	Clock func() time.Time
+	// Logger receives diagnostics about rejected or malformed deliveries; defaults to a no-op.
+	Logger Logger
+}
+
+// WebhookHandler verifies and dispatches namaskah webhook deliveries. It implements
+// http.Handler, so it can be mounted directly on a ServeMux.
+//
+// Register typed callbacks for the events you care about; events whose callback is
+// nil are silently ignored. OnUnknownEvent, if set, receives only event types the
+// handler does not recognise.
+type WebhookHandler struct {
+	config WebhookHandlerConfig
+
+	OnSMSReceived           func(SMSReceivedEvent)
+	OnVerificationCompleted func(VerificationCompletedEvent)
+	OnVerificationCancelled func(VerificationCancelledEvent)
+	OnForwardingFailed      func(ForwardingFailedEvent)
+	OnUnknownEvent          func(eventType string, data json.RawMessage)
+}
+
+// NewWebhookHandler constructs a WebhookHandler, applying defaults for any
+// zero-valued fields on config.
+func NewWebhookHandler(config WebhookHandlerConfig) *WebhookHandler {
+	if config.MaxSkew == 0 {
+		config.MaxSkew = 5 * time.Minute
+	}
+	if config.Clock == nil {
+		config.Clock = time.Now
+	}
+	if config.Logger == nil {
+		config.Logger = noopLogger{}
+	}
+	return &WebhookHandler{config: config}
+}
+
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.verify(r, body); err != nil {
+		h.config.Logger.Printf("namaskah: rejecting webhook: %v", err)
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	var envelope webhookEnvelope
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	h.dispatch(envelope)
+	w.WriteHeader(http.StatusOK)
+}
+
+// verify checks the HMAC signature over the raw body and rejects stale timestamps.
+func (h *WebhookHandler) verify(r *http.Request, body []byte) error {
+	sigHeader := r.Header.Get(signatureHeader)
+	if sigHeader == "" {
+		return invalidSignatureError("missing %s header", signatureHeader)
+	}
+	sig, ok := strings.CutPrefix(sigHeader, "sha256=")
+	if !ok {
+		return invalidSignatureError("unsupported signature scheme in %s", signatureHeader)
+	}
+	got, err := hex.DecodeString(sig)
+	if err != nil {
+		return invalidSignatureError("malformed signature: %v", err)
+	}
+
+	mac := hmac.New(sha256.New, []byte(h.config.Secret))
+	mac.Write(body)
+	want := mac.Sum(nil)
+	if !hmac.Equal(got, want) {
+		return invalidSignatureError("signature mismatch")
+	}
+
+	tsHeader := r.Header.Get(timestampHeader)
+	if tsHeader == "" {
+		return invalidSignatureError("missing %s header", timestampHeader)
+	}
+	ts, err := strconv.ParseInt(tsHeader, 10, 64)
+	if err != nil {
+		return invalidSignatureError("malformed %s header: %v", timestampHeader, err)
+	}
+	skew := h.config.Clock().Sub(time.Unix(ts, 0))
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew > h.config.MaxSkew {
+		return invalidSignatureError("timestamp outside allowed skew of %s", h.config.MaxSkew)
+	}
+
+	return nil
+}
+
+// invalidSignatureError builds an *APIError matching ErrInvalidWebhookSignature,
+// so callers can use errors.Is(err, namaskah.ErrInvalidWebhookSignature).
+func invalidSignatureError(format string, args ...interface{}) error {
+	return &APIError{
+		Code:    ErrInvalidWebhookSignature.Code,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+func (h *WebhookHandler) dispatch(envelope webhookEnvelope) {
+	switch envelope.Type {
+	case "sms.received":
+		if h.OnSMSReceived == nil {
+			return
+		}
+		var event SMSReceivedEvent
+		if err := json.Unmarshal(envelope.Data, &event); err != nil {
+			h.config.Logger.Printf("namaskah: malformed %s payload: %v", envelope.Type, err)
+			return
+		}
+		h.OnSMSReceived(event)
+	case "verification.completed":
+		if h.OnVerificationCompleted == nil {
+			return
+		}
+		var event VerificationCompletedEvent
+		if err := json.Unmarshal(envelope.Data, &event); err != nil {
+			h.config.Logger.Printf("namaskah: malformed %s payload: %v", envelope.Type, err)
+			return
+		}
+		h.OnVerificationCompleted(event)
+	case "verification.cancelled":
+		if h.OnVerificationCancelled == nil {
+			return
+		}
+		var event VerificationCancelledEvent
+		if err := json.Unmarshal(envelope.Data, &event); err != nil {
+			h.config.Logger.Printf("namaskah: malformed %s payload: %v", envelope.Type, err)
+			return
+		}
+		h.OnVerificationCancelled(event)
+	case "forwarding.failed":
+		if h.OnForwardingFailed == nil {
+			return
+		}
+		var event ForwardingFailedEvent
+		if err := json.Unmarshal(envelope.Data, &event); err != nil {
+			h.config.Logger.Printf("namaskah: malformed %s payload: %v", envelope.Type, err)
+			return
+		}
+		h.OnForwardingFailed(event)
+	default:
+		if h.OnUnknownEvent != nil {
+			h.OnUnknownEvent(envelope.Type, envelope.Data)
+		}
+	}
+}
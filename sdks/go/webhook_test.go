@@ -0,0 +1,140 @@
+package namaskah
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func signedRequest(t *testing.T, secret string, body []byte, ts time.Time) *http.Request {
+	t.Helper()
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	sig := hex.EncodeToString(mac.Sum(nil))
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/namaskah", strings.NewReader(string(body)))
+	req.Header.Set(signatureHeader, "sha256="+sig)
+	req.Header.Set(timestampHeader, strconv.FormatInt(ts.Unix(), 10))
+	return req
+}
+
+func TestWebhookHandler_ValidSignatureDispatchesSMSReceived(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	body, _ := json.Marshal(webhookEnvelope{
+		Type:      "sms.received",
+		Timestamp: now.Unix(),
+		Data:      json.RawMessage(`{"verification_id":"v1","phone_number":"+15551234","sms_text":"your code is 482910"}`),
+	})
+
+	var got SMSReceivedEvent
+	called := false
+	h := NewWebhookHandler(WebhookHandlerConfig{
+		Secret: "s3cret",
+		Clock:  func() time.Time { return now },
+	})
+	h.OnSMSReceived = func(e SMSReceivedEvent) {
+		called = true
+		got = e
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, signedRequest(t, "s3cret", body, now))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	if !called {
+		t.Fatal("expected OnSMSReceived to be called")
+	}
+	if got.VerificationID != "v1" {
+		t.Errorf("expected verification id v1, got %s", got.VerificationID)
+	}
+}
+
+func TestWebhookHandler_TamperedBodyRejected(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	body, _ := json.Marshal(webhookEnvelope{
+		Type:      "sms.received",
+		Timestamp: now.Unix(),
+		Data:      json.RawMessage(`{"verification_id":"v1"}`),
+	})
+
+	h := NewWebhookHandler(WebhookHandlerConfig{
+		Secret: "s3cret",
+		Clock:  func() time.Time { return now },
+	})
+	h.OnSMSReceived = func(SMSReceivedEvent) {
+		t.Fatal("callback should not fire for a tampered body")
+	}
+
+	req := signedRequest(t, "s3cret", body, now)
+	tampered := append(append([]byte{}, body...), '!')
+	req.Body = io.NopCloser(bytes.NewReader(tampered))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", rec.Code)
+	}
+}
+
+func TestWebhookHandler_StaleTimestampRejected(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	sent := now.Add(-10 * time.Minute)
+	body, _ := json.Marshal(webhookEnvelope{
+		Type:      "sms.received",
+		Timestamp: sent.Unix(),
+		Data:      json.RawMessage(`{"verification_id":"v1"}`),
+	})
+
+	h := NewWebhookHandler(WebhookHandlerConfig{
+		Secret:  "s3cret",
+		Clock:   func() time.Time { return now },
+		MaxSkew: 5 * time.Minute,
+	})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, signedRequest(t, "s3cret", body, sent))
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for stale timestamp, got %d", rec.Code)
+	}
+}
+
+func TestWebhookHandler_UnknownEventType(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	body, _ := json.Marshal(webhookEnvelope{
+		Type:      "account.upgraded",
+		Timestamp: now.Unix(),
+		Data:      json.RawMessage(`{"plan":"pro"}`),
+	})
+
+	var gotType string
+	h := NewWebhookHandler(WebhookHandlerConfig{
+		Secret: "s3cret",
+		Clock:  func() time.Time { return now },
+	})
+	h.OnUnknownEvent = func(eventType string, data json.RawMessage) {
+		gotType = eventType
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, signedRequest(t, "s3cret", body, now))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	if gotType != "account.upgraded" {
+		t.Errorf("expected OnUnknownEvent to see account.upgraded, got %s", gotType)
+	}
+}